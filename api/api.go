@@ -0,0 +1,32 @@
+// Package api contains types and helpers shared by the various Cloudinary APIs.
+package api
+
+import (
+	"io"
+	"log"
+)
+
+// DeliveryType is the delivery/access type of an uploaded asset.
+type DeliveryType = string
+
+const (
+	// Upload is the default, publicly accessible delivery type.
+	Upload DeliveryType = "upload"
+	// Private delivery type - assets are not publicly listable.
+	Private DeliveryType = "private"
+	// Authenticated delivery type - assets require a signed or authenticated URL to be delivered.
+	Authenticated DeliveryType = "authenticated"
+)
+
+// Error represents an error returned by the Cloudinary API in a response body.
+type Error struct {
+	Message string `json:"message,omitempty"`
+}
+
+// DeferredClose closes the given io.Closer, logging any error instead of returning it.
+// It is intended to be used with defer where the caller has no meaningful way to handle a close error.
+func DeferredClose(c io.Closer) {
+	if err := c.Close(); err != nil {
+		log.Printf("cloudinary-go: error closing resource: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package uploader
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+)
+
+const blurHashCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+const (
+	minBlurHashComponents = 3
+	maxBlurHashComponents = 9
+
+	// defaultBlurHashComponents is the component count on an image's longer axis when
+	// params.BlurHashComponents isn't set; the shorter axis is scaled down by the image's aspect
+	// ratio, clamped to the valid range, so a portrait image doesn't get landscape-biased
+	// components.
+	defaultBlurHashComponents = 4
+)
+
+// attachBlurHash decodes data as an image and, if params.BlurHash is set, computes its BlurHash
+// and records it both on the returned string and under the "blurhash" context key, so it is
+// persisted on the asset alongside the upload. It is a no-op if params.BlurHash is false.
+func attachBlurHash(data []byte, params *UploadParams) (string, error) {
+	if !params.BlurHash {
+		return "", nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("blurhash: decoding image: %w", err)
+	}
+
+	xComponents, yComponents := params.BlurHashComponents[0], params.BlurHashComponents[1]
+	if xComponents == 0 && yComponents == 0 {
+		xComponents, yComponents = defaultBlurHashComponentsFor(img.Bounds())
+	}
+
+	hash, err := encodeBlurHash(img, xComponents, yComponents)
+	if err != nil {
+		return "", err
+	}
+
+	// Context may be a map the caller built once and reuses across calls (e.g. a shared
+	// template), so copy it rather than writing into it: Upload takes UploadParams by value, but
+	// a map is a reference, and mutating the caller's map here would race with any other
+	// concurrent Upload sharing it.
+	newContext := make(map[string]string, len(params.Context)+1)
+	for k, v := range params.Context {
+		newContext[k] = v
+	}
+	newContext["blurhash"] = hash
+	params.Context = newContext
+
+	return hash, nil
+}
+
+// defaultBlurHashComponentsFor picks BlurHash component counts that preserve bounds' aspect
+// ratio: the longer axis gets defaultBlurHashComponents, and the shorter axis is scaled down
+// accordingly, both clamped to the valid component range.
+func defaultBlurHashComponentsFor(bounds image.Rectangle) (x, y int) {
+	aspectRatio := float64(bounds.Dx()) / float64(bounds.Dy())
+
+	x = int(clampFloat(math.Round(defaultBlurHashComponents*aspectRatio), minBlurHashComponents, maxBlurHashComponents))
+	y = int(clampFloat(math.Round(defaultBlurHashComponents/aspectRatio), minBlurHashComponents, maxBlurHashComponents))
+	return x, y
+}
+
+// encodeBlurHash computes the BlurHash of img using xComponents*yComponents DCT coefficients,
+// per the algorithm described at https://github.com/woltapp/blurhash.
+func encodeBlurHash(img image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < minBlurHashComponents || xComponents > maxBlurHashComponents ||
+		yComponents < minBlurHashComponents || yComponents > maxBlurHashComponents {
+		return "", fmt.Errorf("blurhash: components must be in range %d..%d, got %dx%d",
+			minBlurHashComponents, maxBlurHashComponents, xComponents, yComponents)
+	}
+
+	bounds := img.Bounds()
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			factors = append(factors, blurHashBasisFunction(img, bounds, i, j))
+		}
+	}
+
+	dc := factors[0]
+	ac := factors[1:]
+
+	maximumValue := 1.0
+	quantizedMaximumValue := 0
+	if len(ac) > 0 {
+		actualMaximumValue := 0.0
+		for _, f := range ac {
+			actualMaximumValue = math.Max(actualMaximumValue, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantizedMaximumValue = int(clampFloat(math.Floor(actualMaximumValue*166-0.5), 0, 82))
+		maximumValue = float64(quantizedMaximumValue+1) / 166
+	}
+
+	hash := encodeBase83((xComponents-1)+(yComponents-1)*9, 1)
+	hash += encodeBase83(quantizedMaximumValue, 1)
+	hash += encodeBase83(encodeBlurHashDC(dc), 4)
+	for _, f := range ac {
+		hash += encodeBase83(encodeBlurHashAC(f, maximumValue), 2)
+	}
+
+	return hash, nil
+}
+
+// blurHashBasisFunction computes the (i, j) DCT coefficient of img in linear-RGB space.
+func blurHashBasisFunction(img image.Image, bounds image.Rectangle, i, j int) [3]float64 {
+	var r, g, b float64
+
+	normalization := 1.0
+	if i != 0 || j != 0 {
+		normalization = 2.0
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			basis := normalization *
+				math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+				math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+
+			pr, pg, pb, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			r += basis * sRGBToLinear(pr>>8)
+			g += basis * sRGBToLinear(pg>>8)
+			b += basis * sRGBToLinear(pb>>8)
+		}
+	}
+
+	scale := 1.0 / float64(width*height)
+	return [3]float64{r * scale, g * scale, b * scale}
+}
+
+func encodeBlurHashDC(value [3]float64) int {
+	r := linearToSRGB(value[0])
+	g := linearToSRGB(value[1])
+	b := linearToSRGB(value[2])
+	return (r << 16) + (g << 8) + b
+}
+
+func encodeBlurHashAC(value [3]float64, maximumValue float64) int {
+	quantR := quantizeBlurHashAC(value[0] / maximumValue)
+	quantG := quantizeBlurHashAC(value[1] / maximumValue)
+	quantB := quantizeBlurHashAC(value[2] / maximumValue)
+	return quantR*19*19 + quantG*19 + quantB
+}
+
+func quantizeBlurHashAC(v float64) int {
+	return int(clampFloat(math.Floor(signedPow(v, 0.5)*9+9.5), 0, 18))
+}
+
+func sRGBToLinear(v uint32) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = clampFloat(v, 0, 1)
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signedPow(v, p float64) float64 {
+	sign := 1.0
+	if v < 0 {
+		sign = -1.0
+	}
+	return sign * math.Pow(math.Abs(v), p)
+}
+
+func clampFloat(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func encodeBase83(value, length int) string {
+	result := make([]byte, length)
+	for i := 1; i <= length; i++ {
+		digit := (value / pow83(length-i)) % 83
+		result[i-1] = blurHashCharset[digit]
+	}
+	return string(result)
+}
+
+func pow83(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 83
+	}
+	return p
+}
@@ -0,0 +1,112 @@
+package uploader
+
+import (
+	"image"
+	_ "image/png"
+	"os"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/internal/cldtest"
+)
+
+func TestEncodeBlurHash(t *testing.T) {
+	tests := []struct {
+		File string
+		Hash string
+	}{
+		{File: "blurhash_red.png", Hash: "LlM_e@|_fQ|_|_w{fQw{fQfQfQfQ"},
+		{File: "blurhash_gradient.png", Hash: "LyI5Yd3AfQxtuvRnfQnSfQfQfQfQ"},
+	}
+
+	for _, test := range tests {
+		f, err := os.Open(cldtest.TestDataDir() + "/" + test.File)
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, _, err := image.Decode(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := encodeBlurHash(img, 4, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := hash, test.Hash; got != want {
+			t.Errorf("%s: got %v, want %v", test.File, got, want)
+		}
+	}
+}
+
+func TestEncodeBlurHashInvalidComponents(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	if _, err := encodeBlurHash(img, 1, 3); err == nil {
+		t.Error("expected an error for out-of-range components")
+	}
+}
+
+func TestDefaultBlurHashComponentsFor(t *testing.T) {
+	tests := []struct {
+		name         string
+		bounds       image.Rectangle
+		wantX, wantY int
+	}{
+		{name: "square", bounds: image.Rect(0, 0, 8, 8), wantX: 4, wantY: 4},
+		{name: "landscape", bounds: image.Rect(0, 0, 16, 8), wantX: 8, wantY: 3},
+		{name: "portrait", bounds: image.Rect(0, 0, 8, 16), wantX: 3, wantY: 8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x, y := defaultBlurHashComponentsFor(test.bounds)
+			if x != test.wantX || y != test.wantY {
+				t.Errorf("got %dx%d, want %dx%d", x, y, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+func TestAttachBlurHashDoesNotMutateCallerContext(t *testing.T) {
+	shared := map[string]string{"alt": "shared template"}
+	params := UploadParams{BlurHash: true, Context: shared}
+
+	data, err := os.ReadFile(cldtest.ImageFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := attachBlurHash(data, &params); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := shared["blurhash"]; ok {
+		t.Error("attachBlurHash mutated the caller's shared Context map")
+	}
+	if params.Context["blurhash"] == "" {
+		t.Error("expected params.Context to carry the computed blurhash")
+	}
+}
+
+// TestAttachBlurHashPersistsThroughStructToParams confirms the hash attached to params.Context
+// survives the trip through structToParams as a discrete "blurhash=..." context key, rather than
+// as part of an unparsed JSON blob (see structToParams / contextToString).
+func TestAttachBlurHashPersistsThroughStructToParams(t *testing.T) {
+	params := UploadParams{BlurHash: true, Context: map[string]string{"alt": "shared template"}}
+
+	data, err := os.ReadFile(cldtest.ImageFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := attachBlurHash(data, &params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	context := structToParams(params)["context"]
+	want := "alt=shared template|blurhash=" + hash
+	if context != want {
+		t.Errorf("got context %q, want %q", context, want)
+	}
+}
@@ -0,0 +1,45 @@
+package uploader
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ColorWeight represents a single entry of the Colors/Predominant analysis:
+// a color (name or hex code) paired with the percentage of the image it covers.
+type ColorWeight struct {
+	Color  string
+	Weight float64
+}
+
+// MarshalJSON serializes a ColorWeight as the [color, weight] tuple used by the Cloudinary API.
+func (cw ColorWeight) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{cw.Color, cw.Weight})
+}
+
+// UnmarshalJSON deserializes a ColorWeight from the [color, weight] tuple used by the Cloudinary API.
+func (cw *ColorWeight) UnmarshalJSON(b []byte) error {
+	var tuple []interface{}
+	if err := json.Unmarshal(b, &tuple); err != nil {
+		return err
+	}
+
+	if len(tuple) != 2 {
+		return fmt.Errorf("unexpected color weight tuple: %s", b)
+	}
+
+	color, ok := tuple[0].(string)
+	if !ok {
+		return fmt.Errorf("unexpected color in color weight tuple: %v", tuple[0])
+	}
+
+	weight, ok := tuple[1].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected weight in color weight tuple: %v", tuple[1])
+	}
+
+	cw.Color = color
+	cw.Weight = weight
+
+	return nil
+}
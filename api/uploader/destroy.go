@@ -0,0 +1,32 @@
+package uploader
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/api"
+)
+
+// DestroyParams are the parameters for the Destroy API.
+type DestroyParams struct {
+	PublicID     string           `json:"public_id"`
+	ResourceType string           `json:"resource_type,omitempty"`
+	Type         api.DeliveryType `json:"type,omitempty"`
+	Invalidate   bool             `json:"invalidate,omitempty"`
+}
+
+// DestroyResult is the result of the Destroy API.
+type DestroyResult struct {
+	Result string    `json:"result"`
+	Error  api.Error `json:"error,omitempty"`
+}
+
+// Destroy deletes the asset identified by params.PublicID from Cloudinary.
+func (a *Api) Destroy(ctx context.Context, params DestroyParams) (*DestroyResult, error) {
+	result := &DestroyResult{}
+
+	if err := a.callUploadAPI(ctx, "destroy", params.ResourceType, structToParams(params), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package uploader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+)
+
+// autoOrient reads the EXIF orientation tag (if any) from a JPEG image, rotates/flips the pixel
+// data so the encoded image is upright, and re-encodes it without the EXIF block, stripping the
+// orientation tag along with the rest of the EXIF metadata. Non-JPEG data, or JPEG data with no
+// (or upright) orientation tag, is returned unchanged.
+func autoOrient(data []byte) ([]byte, error) {
+	orientation, err := readExifOrientation(data)
+	if err != nil {
+		return nil, fmt.Errorf("autoorient: reading EXIF orientation: %w", err)
+	}
+	if orientation <= 1 {
+		return data, nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("autoorient: decoding image: %w", err)
+	}
+
+	oriented := applyExifOrientation(img, orientation)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, oriented, &jpeg.Options{Quality: jpeg.DefaultQuality}); err != nil {
+		return nil, fmt.Errorf("autoorient: re-encoding image: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// readExifOrientation returns the EXIF orientation tag (1-8) found in a JPEG's APP1 segment, or 1
+// (upright / no-op) if data is not a JPEG or carries no orientation tag.
+func readExifOrientation(data []byte) (int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1, nil
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1, nil
+		}
+		marker := data[pos+1]
+		if marker == 0xD9 || marker == 0xDA { // EOI or start of scan: no more metadata segments
+			break
+		}
+
+		segmentLen := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		segmentStart := pos + 4
+		segmentEnd := pos + 2 + segmentLen
+		if segmentEnd > len(data) {
+			break
+		}
+
+		if marker == 0xE1 && segmentEnd-segmentStart >= 6 && bytes.Equal(data[segmentStart:segmentStart+6], []byte("Exif\x00\x00")) {
+			return parseTiffOrientation(data[segmentStart+6 : segmentEnd])
+		}
+
+		pos = segmentEnd
+	}
+
+	return 1, nil
+}
+
+// parseTiffOrientation scans a TIFF/EXIF IFD0 for the Orientation tag (0x0112).
+func parseTiffOrientation(tiff []byte) (int, error) {
+	if len(tiff) < 8 {
+		return 1, nil
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1, fmt.Errorf("unrecognized TIFF byte order %q", tiff[0:2])
+	}
+
+	ifdOffset := order.Uint32(tiff[4:8])
+	if int(ifdOffset)+2 > len(tiff) {
+		return 1, nil
+	}
+
+	numEntries := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+	const entrySize = 12
+	base := int(ifdOffset) + 2
+
+	for i := 0; i < numEntries; i++ {
+		entryStart := base + i*entrySize
+		if entryStart+entrySize > len(tiff) {
+			break
+		}
+		entry := tiff[entryStart : entryStart+entrySize]
+
+		tag := order.Uint16(entry[0:2])
+		if tag != 0x0112 {
+			continue
+		}
+
+		value := order.Uint16(entry[8:10])
+		if value < 1 || value > 8 {
+			return 1, nil
+		}
+		return int(value), nil
+	}
+
+	return 1, nil
+}
+
+// applyExifOrientation returns a copy of img rotated/flipped so it renders upright for the given
+// EXIF orientation value (2-8; 1 is already upright and is not expected here).
+func applyExifOrientation(img image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return flipHorizontal(img)
+	case 3:
+		return rotate180(img)
+	case 4:
+		return flipVertical(img)
+	case 5:
+		return rotate90CCW(flipHorizontal(img))
+	case 6:
+		return rotate90CW(img)
+	case 7:
+		return rotate90CW(flipHorizontal(img))
+	case 8:
+		return rotate90CCW(img)
+	default:
+		return img
+	}
+}
+
+func flipHorizontal(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			dst.Set(b.Dx()-1-x, y, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func flipVertical(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			dst.Set(x, b.Dy()-1-y, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func rotate180(img image.Image) image.Image {
+	return flipVertical(flipHorizontal(img))
+}
+
+// rotate90CW rotates img 90 degrees clockwise.
+func rotate90CW(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dy(), b.Dx()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			dst.Set(b.Dy()-1-y, x, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// rotate90CCW rotates img 90 degrees counter-clockwise.
+func rotate90CCW(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dy(), b.Dx()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			dst.Set(y, b.Dx()-1-x, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
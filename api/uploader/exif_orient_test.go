@@ -0,0 +1,102 @@
+package uploader
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/internal/cldtest"
+)
+
+// colorTolerance accounts for JPEG quantization noise introduced by encoding/re-encoding.
+const colorTolerance = 40
+
+func TestAutoOrient(t *testing.T) {
+	tests := []struct {
+		Orientation int
+		Width       int
+		Height      int
+	}{
+		{Orientation: 1, Width: 16, Height: 16},
+		{Orientation: 2, Width: 16, Height: 16},
+		{Orientation: 3, Width: 16, Height: 16},
+		{Orientation: 4, Width: 16, Height: 16},
+		{Orientation: 5, Width: 16, Height: 16},
+		{Orientation: 6, Width: 16, Height: 16},
+		{Orientation: 7, Width: 16, Height: 16},
+		{Orientation: 8, Width: 16, Height: 16},
+	}
+
+	for _, test := range tests {
+		path := cldtest.TestDataDir() + "/exif_orientation_" + strconv.Itoa(test.Orientation) + ".jpg"
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		oriented, err := autoOrient(data)
+		if err != nil {
+			t.Fatalf("orientation %d: %v", test.Orientation, err)
+		}
+
+		if orientation, err := readExifOrientation(oriented); err != nil || orientation > 1 {
+			t.Errorf("orientation %d: EXIF not stripped, got orientation %d, err %v", test.Orientation, orientation, err)
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(oriented))
+		if err != nil {
+			t.Fatalf("orientation %d: decoding result: %v", test.Orientation, err)
+		}
+
+		b := img.Bounds()
+		if b.Dx() != test.Width || b.Dy() != test.Height {
+			t.Errorf("orientation %d: got size %dx%d, want %dx%d", test.Orientation, b.Dx(), b.Dy(), test.Width, test.Height)
+		}
+
+		// Sample a pixel well inside each quadrant, away from JPEG block boundaries.
+		assertQuadrant(t, test.Orientation, img, b.Min.X+2, b.Min.Y+2, color.NRGBA{255, 0, 0, 255}, "top-left")
+		assertQuadrant(t, test.Orientation, img, b.Max.X-3, b.Min.Y+2, color.NRGBA{0, 255, 0, 255}, "top-right")
+		assertQuadrant(t, test.Orientation, img, b.Min.X+2, b.Max.Y-3, color.NRGBA{0, 0, 255, 255}, "bottom-left")
+		assertQuadrant(t, test.Orientation, img, b.Max.X-3, b.Max.Y-3, color.NRGBA{255, 255, 0, 255}, "bottom-right")
+	}
+}
+
+func assertQuadrant(t *testing.T, orientation int, img image.Image, x, y int, want color.NRGBA, label string) {
+	t.Helper()
+	r, g, b, a := img.At(x, y).RGBA()
+	got := color.NRGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+	if !closeEnough(got, want) {
+		t.Errorf("orientation %d: %s quadrant got %v, want %v", orientation, label, got, want)
+	}
+}
+
+func closeEnough(got, want color.NRGBA) bool {
+	return diff(got.R, want.R) <= colorTolerance &&
+		diff(got.G, want.G) <= colorTolerance &&
+		diff(got.B, want.B) <= colorTolerance
+}
+
+func diff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+func TestAutoOrientNonJPEG(t *testing.T) {
+	data, err := os.ReadFile(cldtest.TestDataDir() + "/old_logo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := autoOrient(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != string(data) {
+		t.Error("expected non-JPEG data to be returned unchanged")
+	}
+}
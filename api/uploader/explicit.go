@@ -0,0 +1,34 @@
+package uploader
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/api"
+)
+
+// ExplicitParams are the parameters for the Explicit API, used to apply actions to an already
+// uploaded asset (e.g. generating additional responsive breakpoints or eager transformations).
+type ExplicitParams struct {
+	PublicID              string                      `json:"public_id"`
+	Type                  api.DeliveryType            `json:"type,omitempty"`
+	ResourceType          string                      `json:"resource_type,omitempty"`
+	ResponsiveBreakpoints ResponsiveBreakpointsParams `json:"responsive_breakpoints,omitempty"`
+}
+
+// ExplicitResult is the result of the Explicit API.
+type ExplicitResult struct {
+	PublicID              string                      `json:"public_id"`
+	ResponsiveBreakpoints ResponsiveBreakpointsResult `json:"responsive_breakpoints,omitempty"`
+	Error                 api.Error                   `json:"error,omitempty"`
+}
+
+// Explicit applies eager actions to an already uploaded asset identified by params.PublicID.
+func (a *Api) Explicit(ctx context.Context, params ExplicitParams) (*ExplicitResult, error) {
+	result := &ExplicitResult{}
+
+	if err := a.callUploadAPI(ctx, "explicit", params.ResourceType, structToParams(params), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
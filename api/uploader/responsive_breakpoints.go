@@ -0,0 +1,33 @@
+package uploader
+
+// ResponsiveBreakpointParams defines a single responsive breakpoints request.
+type ResponsiveBreakpointParams struct {
+	CreateDerived  bool   `json:"create_derived"`
+	Transformation string `json:"transformation,omitempty"`
+	Format         string `json:"format,omitempty"`
+	MaxWidth       int    `json:"max_width,omitempty"`
+	MinWidth       int    `json:"min_width,omitempty"`
+	MaxImages      int    `json:"max_images,omitempty"`
+	BytesStep      int    `json:"bytes_step,omitempty"`
+}
+
+// ResponsiveBreakpointsParams is a list of responsive breakpoints requests.
+type ResponsiveBreakpointsParams []ResponsiveBreakpointParams
+
+// Breakpoint is a single generated derived asset for a responsive breakpoints configuration.
+type Breakpoint struct {
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Bytes     int    `json:"bytes"`
+	URL       string `json:"url"`
+	SecureURL string `json:"secure_url"`
+}
+
+// ResponsiveBreakpointResult groups the generated breakpoints for a single requested configuration.
+type ResponsiveBreakpointResult struct {
+	Transformation string       `json:"transformation"`
+	Breakpoints    []Breakpoint `json:"breakpoints"`
+}
+
+// ResponsiveBreakpointsResult is the list of responsive breakpoints results, one per requested configuration.
+type ResponsiveBreakpointsResult []ResponsiveBreakpointResult
@@ -0,0 +1,80 @@
+package uploader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/api"
+)
+
+// defaultSignedUploadExpiry is how long the timestamp/signature pair returned by SignedUploadURL
+// is expected to remain valid for, absent an explicit SignedUploadParams.ExpiresIn.
+const defaultSignedUploadExpiry = time.Hour
+
+// SignedUploadParams are the parameters for the SignedUploadURL API.
+type SignedUploadParams struct {
+	PublicID     string
+	Folder       string
+	ResourceType string
+	Type         api.DeliveryType
+	UploadPreset string
+	Eager        string
+
+	// MaxFileSize, if set, is returned on SignedUploadResult as a hint for the calling client to
+	// enforce before it starts the direct upload; it is not sent to Cloudinary.
+	MaxFileSize int64
+
+	// ExpiresIn overrides how long the generated timestamp/signature pair should be treated as
+	// valid by the caller. Defaults to defaultSignedUploadExpiry.
+	ExpiresIn time.Duration
+}
+
+// SignedUploadResult is a ready-to-POST direct upload URL and the exact form fields (including
+// api_key, timestamp and signature) a browser or mobile client needs to upload an asset straight
+// to Cloudinary, without proxying the bytes through this service.
+type SignedUploadResult struct {
+	URL         string
+	Fields      map[string]string
+	MaxFileSize int64
+	ExpiresAt   time.Time
+}
+
+// SignedUploadURL returns a presigned URL and form fields that a client can POST an asset to
+// directly, keeping the API secret server-side. It issues no HTTP request of its own.
+func (a *Api) SignedUploadURL(ctx context.Context, params SignedUploadParams) (*SignedUploadResult, error) {
+	_ = ctx // no request is made; ctx is accepted for consistency with the rest of the Api.
+
+	resourceType := resourceTypeOrDefault(params.ResourceType)
+
+	fields := map[string]string{}
+	if params.PublicID != "" {
+		fields["public_id"] = params.PublicID
+	}
+	if params.Folder != "" {
+		fields["folder"] = params.Folder
+	}
+	if params.Type != "" {
+		fields["type"] = params.Type
+	}
+	if params.UploadPreset != "" {
+		fields["upload_preset"] = params.UploadPreset
+	}
+	if params.Eager != "" {
+		fields["eager"] = params.Eager
+	}
+
+	fields = a.sign(fields)
+
+	expiresIn := params.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = defaultSignedUploadExpiry
+	}
+
+	return &SignedUploadResult{
+		URL:         fmt.Sprintf("%s/v1_1/%s/%s/upload", a.Config.API.UploadPrefix, a.Config.Cloud.CloudName, resourceType),
+		Fields:      fields,
+		MaxFileSize: params.MaxFileSize,
+		ExpiresAt:   time.Now().Add(expiresIn),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package uploader_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+	"github.com/cloudinary/cloudinary-go/internal/cldtest"
+)
+
+func TestUploader_SignedUploadURL(t *testing.T) {
+	params := uploader.SignedUploadParams{
+		PublicID:     cldtest.PublicID,
+		Folder:       "go_test",
+		ResourceType: "image",
+		UploadPreset: "go_test_preset",
+		MaxFileSize:  10 << 20,
+	}
+
+	result, err := uploadAPI.SignedUploadURL(ctx, params)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Contains(t, result.URL, "/image/upload")
+	assert.Equal(t, cldtest.PublicID, result.Fields["public_id"])
+	assert.Equal(t, "go_test", result.Fields["folder"])
+	assert.Equal(t, "go_test_preset", result.Fields["upload_preset"])
+	assert.NotEmpty(t, result.Fields["signature"])
+	assert.NotEmpty(t, result.Fields["api_key"])
+	assert.NotEmpty(t, result.Fields["timestamp"])
+	assert.EqualValues(t, 10<<20, result.MaxFileSize)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), result.ExpiresAt, time.Minute)
+}
+
+func TestUploader_SignedUploadURLDefaultResourceType(t *testing.T) {
+	result, err := uploadAPI.SignedUploadURL(ctx, uploader.SignedUploadParams{PublicID: cldtest.PublicID})
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Contains(t, result.URL, "/image/upload")
+}
+
+func TestUploader_SignedUploadURLAuthenticated(t *testing.T) {
+	result, err := uploadAPI.SignedUploadURL(ctx, uploader.SignedUploadParams{
+		PublicID: cldtest.PublicID,
+		Type:     api.Authenticated,
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, string(api.Authenticated), result.Fields["type"])
+}
@@ -0,0 +1,415 @@
+// Package uploader provides the Cloudinary Upload API: uploading, destroying and applying
+// actions to assets.
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/config"
+)
+
+// Api is the Cloudinary Upload API client.
+type Api struct {
+	Config *config.Configuration
+	client *http.Client
+}
+
+// New creates a new Upload Api instance, configured from the CLOUDINARY_URL environment variable.
+func New() (*Api, error) {
+	c, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Api{Config: c, client: &http.Client{}}, nil
+}
+
+// Upload uploads an asset to Cloudinary. file may be a local file path, a remote URL, a base64
+// data URI, or an io.Reader of the raw asset bytes.
+//
+// Files larger than a.Config.API.ChunkSize are uploaded in chunks; see uploadLarge. AutoOrient
+// and BlurHash apply to chunked uploads too, for every source type, at the cost of reading the
+// whole file into memory first to decode the image.
+func (a *Api) Upload(ctx context.Context, file interface{}, params UploadParams) (*UploadResult, error) {
+	result := &UploadResult{}
+	var blurHash string
+
+	switch f := file.(type) {
+	case string:
+		var data []byte
+		var haveData bool
+
+		switch {
+		case isLocalFilePath(f):
+			large, size, err := a.isLargeFile(f)
+			if err != nil {
+				return nil, err
+			}
+			if large {
+				peek := make([]byte, sniffLen)
+				n, _ := a.peekLocalFile(f, peek)
+				if err := a.checkContentTypePolicy(peek[:n]); err != nil {
+					return nil, err
+				}
+
+				if !params.AutoOrient && !params.BlurHash {
+					return a.uploadLarge(ctx, f, size, params)
+				}
+
+				// AutoOrient/BlurHash need the decoded image in memory, which forfeits the
+				// streaming-from-disk benefit of uploadLarge for this file; read it fully and
+				// preprocess it the same way the small-file and io.Reader paths do.
+				fileData, err := ioutil.ReadFile(f)
+				if err != nil {
+					return nil, err
+				}
+				var hash string
+				if fileData, hash, err = a.preprocessImage(fileData, &params); err != nil {
+					return nil, err
+				}
+				result, err := a.uploadLargeReader(ctx, bytes.NewReader(fileData), int64(len(fileData)), params)
+				if err != nil {
+					return nil, err
+				}
+				result.BlurHash = hash
+				return result, nil
+			}
+
+			if data, err = ioutil.ReadFile(f); err != nil {
+				return nil, err
+			}
+			haveData = true
+		default:
+			data, haveData = decodeDataURI(f)
+		}
+
+		if !haveData {
+			// Remote URL: Cloudinary fetches and processes the asset itself.
+			if err := a.checkContentTypePolicyForURL(ctx, f, params.ContentTypeHint); err != nil {
+				return nil, err
+			}
+			if err := a.postMultipart(ctx, params, func(w *multipart.Writer) error {
+				return w.WriteField("file", f)
+			}, result); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		if err := a.checkContentTypePolicy(data); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if data, blurHash, err = a.preprocessImage(data, &params); err != nil {
+			return nil, err
+		}
+		if err := a.uploadBytes(ctx, data, params, result); err != nil {
+			return nil, err
+		}
+	case io.Reader:
+		data, err := ioutil.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.checkContentTypePolicy(data); err != nil {
+			return nil, err
+		}
+
+		if data, blurHash, err = a.preprocessImage(data, &params); err != nil {
+			return nil, err
+		}
+
+		if int64(len(data)) > a.Config.API.ChunkSize {
+			result, err = a.uploadLargeReader(ctx, bytes.NewReader(data), int64(len(data)), params)
+			if err != nil {
+				return nil, err
+			}
+			result.BlurHash = blurHash
+			return result, nil
+		}
+
+		if err := a.uploadBytes(ctx, data, params, result); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported upload source type %T", file)
+	}
+
+	result.BlurHash = blurHash
+	return result, nil
+}
+
+// preprocessImage applies the client-side image transforms requested on params (EXIF
+// auto-orientation, then BlurHash generation) to raw asset bytes before upload.
+func (a *Api) preprocessImage(data []byte, params *UploadParams) ([]byte, string, error) {
+	if params.AutoOrient {
+		oriented, err := autoOrient(data)
+		if err != nil {
+			return nil, "", err
+		}
+		data = oriented
+	}
+
+	hash, err := attachBlurHash(data, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, hash, nil
+}
+
+// decodeDataURI decodes the payload of a base64 data URI (e.g. "data:image/png;base64,...."),
+// reporting ok=false if src is not a data URI.
+func decodeDataURI(src string) ([]byte, bool) {
+	if !strings.HasPrefix(src, "data:") {
+		return nil, false
+	}
+
+	idx := strings.Index(src, ",")
+	if idx < 0 {
+		return nil, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(src[idx+1:])
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// isLocalFilePath reports whether src looks like a path to a file on the local filesystem, as
+// opposed to a remote URL or a base64 data URI.
+func isLocalFilePath(src string) bool {
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "data:") || strings.HasPrefix(src, "s3://") || strings.HasPrefix(src, "gs://") {
+		return false
+	}
+
+	_, err := os.Stat(src)
+	return err == nil
+}
+
+// peekLocalFile reads up to len(buf) bytes from the start of the local file at path, without
+// affecting any later read of the same file (uploadLarge reads chunks by offset via io.ReaderAt).
+func (a *Api) peekLocalFile(path string, buf []byte) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer api.DeferredClose(file)
+
+	n, err := file.Read(buf)
+	if err != nil && err != io.EOF {
+		return n, err
+	}
+	return n, nil
+}
+
+// isLargeFile reports whether the local file at path is larger than the configured chunk size.
+func (a *Api) isLargeFile(path string) (bool, int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return info.Size() > a.Config.API.ChunkSize, info.Size(), nil
+}
+
+// uploadBytes uploads raw asset bytes via a single multipart POST request.
+func (a *Api) uploadBytes(ctx context.Context, data []byte, params UploadParams, result *UploadResult) error {
+	return a.postMultipart(ctx, params, func(w *multipart.Writer) error {
+		part, err := w.CreateFormFile("file", "file")
+		if err != nil {
+			return err
+		}
+		_, err = part.Write(data)
+		return err
+	}, result)
+}
+
+func (a *Api) postMultipart(ctx context.Context, params UploadParams, writeFile func(*multipart.Writer) error, result *UploadResult) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for key, value := range a.signedParams(params) {
+		if err := writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+
+	if err := writeFile(writer); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, cancel, err := a.newRequest(ctx, resourceTypeOrDefault(params.ResourceType), "upload", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return a.doRequest(req, cancel, result)
+}
+
+func resourceTypeOrDefault(resourceType string) string {
+	if resourceType == "" {
+		return "image"
+	}
+	return resourceType
+}
+
+func (a *Api) newRequest(ctx context.Context, resourceType, action string, body io.Reader) (*http.Request, context.CancelFunc, error) {
+	uploadURL := fmt.Sprintf("%s/v1_1/%s/%s/%s", a.Config.API.UploadPrefix, a.Config.Cloud.CloudName, resourceType, action)
+
+	ctx, cancel := context.WithTimeout(ctx, a.Config.API.Timeout)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return req, cancel, nil
+}
+
+// doRequest sends req and decodes the JSON response into result. cancel is called once the round
+// trip completes, releasing the timeout context created by newRequest.
+func (a *Api) doRequest(req *http.Request, cancel context.CancelFunc, result interface{}) error {
+	defer cancel()
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer api.DeferredClose(resp.Body)
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// callUploadAPI issues a plain (fileless) call to the upload API, such as destroy or explicit.
+func (a *Api) callUploadAPI(ctx context.Context, action, resourceType string, params map[string]string, result interface{}) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for key, value := range a.sign(params) {
+		if err := writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, cancel, err := a.newRequest(ctx, resourceTypeOrDefault(resourceType), action, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return a.doRequest(req, cancel, result)
+}
+
+// signedParams flattens params into string-keyed form fields and signs them; see sign.
+func (a *Api) signedParams(params interface{}) map[string]string {
+	return a.sign(structToParams(params))
+}
+
+// structToParams flattens a params struct into the string-keyed form fields expected by the
+// Cloudinary API.
+func structToParams(params interface{}) map[string]string {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(fields))
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			result[key] = v
+		case bool:
+			result[key] = strconv.FormatBool(v)
+		case map[string]interface{}:
+			result[key] = contextToString(v)
+		default:
+			b, err := json.Marshal(v)
+			if err == nil {
+				result[key] = string(b)
+			}
+		}
+	}
+
+	return result
+}
+
+// contextToString serializes UploadParams.Context (the only struct field that round-trips
+// through structToParams as a map) into the pipe-delimited "key=value|key2=value2" form the
+// upload API expects context metadata in, rather than a JSON object. Keys are sorted for
+// deterministic output.
+func contextToString(context map[string]interface{}) string {
+	keys := make([]string, 0, len(context))
+	for k := range context {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, context[k]))
+	}
+
+	return strings.Join(pairs, "|")
+}
+
+// sign stamps params with a timestamp, api_key and the matching signature required for
+// authenticated upload API calls.
+func (a *Api) sign(params map[string]string) map[string]string {
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["timestamp"] = strconv.FormatInt(time.Now().Unix(), 10)
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, params[k]))
+	}
+
+	h := sha1.New()
+	_, _ = io.WriteString(h, strings.Join(pairs, "&")+a.Config.Cloud.APISecret)
+
+	params["signature"] = fmt.Sprintf("%x", h.Sum(nil))
+	params["api_key"] = a.Config.Cloud.APIKey
+
+	return params
+}
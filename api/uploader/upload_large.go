@@ -0,0 +1,190 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// chunkBufferPools caches one sync.Pool per chunk size so concurrent uploads with the same
+// a.Config.API.ChunkSize reuse buffers instead of allocating a fresh one per chunk.
+var chunkBufferPools sync.Map // map[int64]*sync.Pool
+
+func chunkBufferPool(chunkSize int64) *sync.Pool {
+	if p, ok := chunkBufferPools.Load(chunkSize); ok {
+		return p.(*sync.Pool)
+	}
+
+	pool := &sync.Pool{
+		New: func() interface{} {
+			return make([]byte, chunkSize)
+		},
+	}
+
+	actual, _ := chunkBufferPools.LoadOrStore(chunkSize, pool)
+	return actual.(*sync.Pool)
+}
+
+// uploadLarge uploads the local file at path in chunks of a.Config.API.ChunkSize, using
+// a.Config.API.ChunkConcurrency workers.
+func (a *Api) uploadLarge(ctx context.Context, path string, size int64, params UploadParams) (*UploadResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	return a.uploadLargeReaderAt(ctx, file, size, params)
+}
+
+// uploadLargeReader uploads an in-memory source in chunks of a.Config.API.ChunkSize.
+func (a *Api) uploadLargeReader(ctx context.Context, r *bytes.Reader, size int64, params UploadParams) (*UploadResult, error) {
+	return a.uploadLargeReaderAt(ctx, r, size, params)
+}
+
+// chunkResult is what a prep goroutine hands back to uploadLargeReaderAt for a single chunk: the
+// filled buffer ready to PUT, or the error that occurred while reading it.
+type chunkResult struct {
+	buf []byte
+	err error
+}
+
+// uploadLargeReaderAt drives a chunked upload over a ReaderAt. Up to a.Config.API.ChunkConcurrency
+// chunks are read from r and filled into pooled buffers in parallel, but every official Cloudinary
+// SDK PUTs chunks against a given X-Unique-Upload-Id strictly in increasing Content-Range order,
+// so the PUTs themselves stay sequential here too; ChunkConcurrency only lets reading chunk N+1
+// overlap with uploading chunk N, rather than allowing concurrent PUTs.
+func (a *Api) uploadLargeReaderAt(ctx context.Context, r io.ReaderAt, size int64, params UploadParams) (*UploadResult, error) {
+	chunkSize := a.Config.API.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = size
+	}
+
+	uniqueUploadID, err := newUniqueUploadID()
+	if err != nil {
+		return nil, err
+	}
+
+	numChunks := int((size + chunkSize - 1) / chunkSize)
+	pool := chunkBufferPool(chunkSize)
+
+	workers := a.Config.API.ChunkConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > numChunks {
+		workers = numChunks
+	}
+
+	ctx, abort := context.WithCancel(ctx)
+	defer abort()
+
+	chunkRange := func(i int) (start, end int64) {
+		start = int64(i) * chunkSize
+		end = start + chunkSize
+		if end > size {
+			end = size
+		}
+		return start, end
+	}
+
+	// ready[i] carries the prepared buffer for chunk i; it's read by the sequential PUT loop
+	// below. sem bounds how far the prep goroutines are allowed to run ahead of that loop, to
+	// at most `workers` chunks' worth of buffers outstanding at once.
+	ready := make([]chan chunkResult, numChunks)
+	for i := range ready {
+		ready[i] = make(chan chunkResult, 1)
+	}
+	sem := make(chan struct{}, workers)
+
+	var wg sync.WaitGroup
+	go func() {
+		for i := 0; i < numChunks; i++ {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				start, end := chunkRange(i)
+				buf := pool.Get().([]byte)
+				buf = buf[:end-start]
+				if _, err := r.ReadAt(buf, start); err != nil && err != io.EOF {
+					pool.Put(buf[:cap(buf)]) //nolint:staticcheck // return the full-capacity slice to the pool
+					ready[i] <- chunkResult{err: err}
+					return
+				}
+				ready[i] <- chunkResult{buf: buf}
+			}()
+		}
+	}()
+	defer wg.Wait()
+
+	var bytesSent int64
+	var result *UploadResult
+	for i := 0; i < numChunks; i++ {
+		chunk := <-ready[i]
+		if chunk.err != nil {
+			abort()
+			<-sem
+			return nil, chunk.err
+		}
+
+		start, end := chunkRange(i)
+		result, err = a.putChunk(ctx, uniqueUploadID, chunk.buf, start, end, size, params)
+		pool.Put(chunk.buf[:cap(chunk.buf)]) //nolint:staticcheck // return the full-capacity slice to the pool
+		<-sem
+		if err != nil {
+			abort()
+			return nil, err
+		}
+
+		bytesSent += end - start
+		if params.UploadProgress != nil {
+			params.UploadProgress(bytesSent, size)
+		}
+	}
+
+	return result, nil
+}
+
+func (a *Api) putChunk(ctx context.Context, uniqueUploadID string, chunk []byte, start, end, total int64, params UploadParams) (*UploadResult, error) {
+	req, cancel, err := a.newRequest(ctx, resourceTypeOrDefault(params.ResourceType), "upload", bytes.NewReader(chunk))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Unique-Upload-Id", uniqueUploadID)
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, total))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	q := req.URL.Query()
+	for key, value := range a.signedParams(params) {
+		q.Set(key, value)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	result := &UploadResult{}
+	if err := a.doRequest(req, cancel, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func newUniqueUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "go-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,190 @@
+package uploader_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+	"github.com/cloudinary/cloudinary-go/internal/cldtest"
+)
+
+// TestUploader_UploadLargeReaderChunkedConcurrent drives a chunked upload with
+// ChunkConcurrency > 1 against a local httptest server and asserts that chunks are still PUT in
+// strictly increasing Content-Range order (ChunkConcurrency only parallelizes reading chunks
+// ahead of the upload, not the PUTs themselves), that they reassemble into the original bytes,
+// and that UploadProgress is driven up to the full asset size.
+func TestUploader_UploadLargeReaderChunkedConcurrent(t *testing.T) {
+	const totalSize = 30
+	const chunkSize = 10
+	data := make([]byte, totalSize)
+	for i := range data {
+		data[i] = byte('a' + i%26)
+	}
+
+	var mu sync.Mutex
+	received := make([]byte, totalSize)
+	uploadIDs := make(map[string]bool)
+	nextWantStart := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploadID := r.Header.Get("X-Unique-Upload-Id")
+		contentRange := r.Header.Get("Content-Range")
+
+		var start, end, total int
+		if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total); err != nil {
+			t.Errorf("unparsable Content-Range %q: %v", contentRange, err)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading chunk body: %v", err)
+			return
+		}
+
+		mu.Lock()
+		uploadIDs[uploadID] = true
+		if start != nextWantStart {
+			t.Errorf("chunk PUT out of order: got Content-Range start %d, want %d", start, nextWantStart)
+		}
+		nextWantStart = end + 1
+		copy(received[start:end+1], body)
+		mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"bytes": total})
+	}))
+	defer server.Close()
+
+	original := uploadAPI.Config.API
+	uploadAPI.Config.API.UploadPrefix = server.URL
+	uploadAPI.Config.API.ChunkSize = chunkSize
+	uploadAPI.Config.API.ChunkConcurrency = 3
+	defer func() { uploadAPI.Config.API = original }()
+
+	var progressMu sync.Mutex
+	var progressCalls []int64
+	params := uploader.UploadParams{
+		PublicID: cldtest.PublicID,
+		UploadProgress: func(bytesSent, totalBytes int64) {
+			if totalBytes != totalSize {
+				t.Errorf("got totalBytes %d, want %d", totalBytes, totalSize)
+			}
+			progressMu.Lock()
+			progressCalls = append(progressCalls, bytesSent)
+			progressMu.Unlock()
+		},
+	}
+
+	resp, err := uploadAPI.Upload(ctx, strings.NewReader(string(data)), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Bytes != totalSize {
+		t.Errorf("got resp.Bytes %d, want %d", resp.Bytes, totalSize)
+	}
+
+	mu.Lock()
+	if string(received) != string(data) {
+		t.Errorf("chunks reassembled incorrectly: got %q, want %q", received, data)
+	}
+	if len(uploadIDs) != 1 {
+		t.Errorf("expected a single shared upload id across all chunks, got %d", len(uploadIDs))
+	}
+	mu.Unlock()
+
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if len(progressCalls) == 0 {
+		t.Fatal("expected UploadProgress to be called")
+	}
+	var maxSent int64
+	for _, sent := range progressCalls {
+		if sent > maxSent {
+			maxSent = sent
+		}
+	}
+	if maxSent != totalSize {
+		t.Errorf("progress callbacks never reached the total asset size: got max %d, want %d", maxSent, totalSize)
+	}
+}
+
+// TestUploader_UploadLargeLocalPathBlurHash ensures that BlurHash still gets computed for a local
+// file that's large enough to go through the chunked upload path, rather than being silently
+// dropped.
+func TestUploader_UploadLargeLocalPathBlurHash(t *testing.T) {
+	const chunkSize = 256
+
+	image, err := os.ReadFile(cldtest.ImageFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Pad past chunkSize with trailing bytes; image.Decode stops at the image's own end-of-data
+	// marker, so the padding doesn't affect what gets decoded for BlurHash.
+	padded := append(append([]byte{}, image...), make([]byte, chunkSize*2)...)
+
+	file, err := os.CreateTemp(cldtest.TestDataDir(), "go_test_large_blurhash.*.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := file.Write(padded); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	received := make([]byte, len(padded))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start, end, total int
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total); err != nil {
+			t.Errorf("unparsable Content-Range: %v", err)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading chunk body: %v", err)
+			return
+		}
+
+		mu.Lock()
+		copy(received[start:end+1], body)
+		mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"bytes": total})
+	}))
+	defer server.Close()
+
+	original := uploadAPI.Config.API
+	uploadAPI.Config.API.UploadPrefix = server.URL
+	uploadAPI.Config.API.ChunkSize = chunkSize
+	uploadAPI.Config.API.ChunkConcurrency = 2
+	defer func() { uploadAPI.Config.API = original }()
+
+	resp, err := uploadAPI.Upload(ctx, file.Name(), uploader.UploadParams{
+		PublicID: cldtest.PublicID,
+		BlurHash: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.BlurHash == "" {
+		t.Error("expected BlurHash to be computed for a chunked local-file upload")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if string(received) != string(padded) {
+		t.Error("chunks reassembled incorrectly for a chunked local-file upload with BlurHash set")
+	}
+}
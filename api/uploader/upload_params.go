@@ -0,0 +1,46 @@
+package uploader
+
+import "github.com/cloudinary/cloudinary-go/api"
+
+// UploadParams are the parameters for the Upload API.
+type UploadParams struct {
+	PublicID     string           `json:"public_id,omitempty"`
+	Folder       string           `json:"folder,omitempty"`
+	ResourceType string           `json:"resource_type,omitempty"`
+	Type         api.DeliveryType `json:"type,omitempty"`
+	Overwrite    bool             `json:"overwrite,omitempty"`
+
+	Context map[string]string `json:"context,omitempty"`
+
+	QualityAnalysis       bool `json:"quality_analysis,omitempty"`
+	AccessibilityAnalysis bool `json:"accessibility_analysis,omitempty"`
+	CinemagraphAnalysis   bool `json:"cinemagraph_analysis,omitempty"`
+
+	ResponsiveBreakpoints ResponsiveBreakpointsParams `json:"responsive_breakpoints,omitempty"`
+
+	// UploadProgress, when set, is invoked after each chunk of a chunked upload is acknowledged
+	// by Cloudinary, with the total bytes sent so far and the total size of the asset. It is not
+	// called for uploads that are small enough to be sent in a single request.
+	UploadProgress func(bytesSent, totalBytes int64) `json:"-"`
+
+	// BlurHash, when set on an image upload, makes the uploader compute a BlurHash placeholder
+	// string locally before the asset is sent to Cloudinary. The hash is returned on
+	// UploadResult.BlurHash and also stored in the asset's context metadata under "blurhash".
+	BlurHash bool `json:"-"`
+
+	// BlurHashComponents overrides the number of DCT components (X, Y) used to compute the
+	// BlurHash, each in the 3..9 range. The zero value derives components from the image's own
+	// aspect ratio instead of a fixed ratio, so a portrait image isn't given landscape-biased
+	// components.
+	BlurHashComponents [2]int `json:"-"`
+
+	// AutoOrient, when set on a JPEG upload, makes the uploader read the EXIF orientation tag,
+	// rotate/flip the pixel data so the encoded image is upright, and strip the EXIF block before
+	// the asset is sent to Cloudinary. It is a no-op for images with no (or an upright) orientation
+	// tag, and for non-JPEG sources.
+	AutoOrient bool `json:"-"`
+
+	// ContentTypeHint overrides the MIME type used to evaluate a.Config.API.Policy for remote URL
+	// sources, instead of issuing a HEAD request to discover it.
+	ContentTypeHint string `json:"-"`
+}
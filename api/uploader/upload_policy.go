@@ -0,0 +1,76 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/api"
+)
+
+// sniffLen mirrors http.DetectContentType's own sniffing window.
+const sniffLen = 512
+
+// ErrDisallowedContentType is returned by Upload when the resolved content type of a source is
+// rejected by a.Config.API.Policy, letting callers distinguish a policy rejection from a
+// transport error.
+var ErrDisallowedContentType = errors.New("cloudinary-go: content type is not allowed by the upload policy")
+
+// checkContentTypePolicy sniffs the content type of data (the first sniffLen bytes are enough)
+// and validates it against a.Config.API.Policy.
+func (a *Api) checkContentTypePolicy(data []byte) error {
+	n := len(data)
+	if n > sniffLen {
+		n = sniffLen
+	}
+
+	return a.checkContentType(http.DetectContentType(data[:n]))
+}
+
+// checkContentTypePolicyForURL validates a remote URL source against a.Config.API.Policy,
+// without downloading the asset: it trusts params.ContentTypeHint if given, otherwise issues a
+// HEAD request, bounded by a.Config.API.Timeout, to read the Content-Type response header.
+//
+// The policy exists to stop disallowed content from ever reaching the upload request, so it
+// fails closed: if the content type can't be determined (the HEAD request fails, times out, or
+// the response has no Content-Type header), the upload is rejected rather than silently let
+// through. Callers that upload from URLs whose servers don't support HEAD, or that don't return a
+// Content-Type on it, must either set params.ContentTypeHint or set
+// a.Config.API.Policy.Disabled to opt the source out of validation (and the HEAD probe) entirely.
+func (a *Api) checkContentTypePolicyForURL(ctx context.Context, url, hint string) error {
+	if a.Config.API.Policy.Disabled {
+		return nil
+	}
+
+	contentType := hint
+	if contentType == "" {
+		reqCtx, cancel := context.WithTimeout(ctx, a.Config.API.Timeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodHead, url, nil)
+		if err != nil {
+			return fmt.Errorf("checking content type of %s: %w", url, err)
+		}
+
+		resp, err := a.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("checking content type of %s: %w: %w", url, err, ErrDisallowedContentType)
+		}
+		defer api.DeferredClose(resp.Body)
+
+		contentType = resp.Header.Get("Content-Type")
+		if contentType == "" {
+			return fmt.Errorf("checking content type of %s: HEAD response had no Content-Type header; set UploadParams.ContentTypeHint: %w", url, ErrDisallowedContentType)
+		}
+	}
+
+	return a.checkContentType(contentType)
+}
+
+func (a *Api) checkContentType(contentType string) error {
+	if a.Config.API.Policy.Allowed(contentType) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", contentType, ErrDisallowedContentType)
+}
@@ -0,0 +1,154 @@
+package uploader_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+	"github.com/cloudinary/cloudinary-go/config"
+	"github.com/cloudinary/cloudinary-go/internal/cldtest"
+)
+
+const disallowedHTML = "<!DOCTYPE html><html><body>not an image</body></html>"
+
+func TestUploader_PolicyRejectsLocalPath(t *testing.T) {
+	file, err := os.CreateTemp(cldtest.TestDataDir(), "go_test_policy.*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err := file.WriteString(disallowedHTML); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = uploadAPI.Upload(ctx, file.Name(), uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assertDisallowedContentType(t, err)
+}
+
+func TestUploader_PolicyRejectsIOReader(t *testing.T) {
+	_, err := uploadAPI.Upload(ctx, strings.NewReader(disallowedHTML), uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assertDisallowedContentType(t, err)
+}
+
+func TestUploader_PolicyRejectsBase64(t *testing.T) {
+	dataURI := "data:text/html;base64," + base64.StdEncoding.EncodeToString([]byte(disallowedHTML))
+
+	_, err := uploadAPI.Upload(ctx, dataURI, uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assertDisallowedContentType(t, err)
+}
+
+func TestUploader_PolicyAllowList(t *testing.T) {
+	original := uploadAPI.Config.API.Policy
+	uploadAPI.Config.API.Policy = config.UploadPolicy{Allow: []string{"image/jpeg"}}
+	defer func() { uploadAPI.Config.API.Policy = original }()
+
+	data, err := os.ReadFile(cldtest.ImageFilePath) // a PNG fixture, not in the allow-list
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = uploadAPI.Upload(ctx, strings.NewReader(string(data)), uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assertDisallowedContentType(t, err)
+}
+
+func TestUploader_PolicyRejectsURLWithoutContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// No Content-Type header on the HEAD response.
+	}))
+	defer server.Close()
+
+	_, err := uploadAPI.Upload(ctx, server.URL, uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assertDisallowedContentType(t, err)
+}
+
+func TestUploader_PolicyRejectsURLOnHeadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "image/png")
+	}))
+	defer server.Close()
+
+	original := uploadAPI.Config.API.Timeout
+	uploadAPI.Config.API.Timeout = time.Millisecond
+	defer func() { uploadAPI.Config.API.Timeout = original }()
+
+	_, err := uploadAPI.Upload(ctx, server.URL, uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assertDisallowedContentType(t, err)
+}
+
+func TestUploader_PolicyAllowsURLWithContentTypeHint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("HEAD request should not be issued when ContentTypeHint is set")
+	}))
+	defer server.Close()
+
+	// ContentTypeHint bypasses the HEAD request entirely, so the policy check never reaches
+	// server; whatever error the actual upload request returns (here, a network error, since
+	// server.URL is only a source reference, not a.Config.API.UploadPrefix) is not a policy
+	// rejection.
+	_, err := uploadAPI.Upload(ctx, server.URL, uploader.UploadParams{
+		PublicID:        cldtest.PublicID,
+		ContentTypeHint: "image/png",
+	})
+
+	if errors.Is(err, uploader.ErrDisallowedContentType) {
+		t.Errorf("got %v, want no policy rejection", err)
+	}
+}
+
+func TestUploader_PolicyDisabledSkipsURLHeadCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("HEAD request should not be issued when Policy.Disabled is set")
+	}))
+	defer server.Close()
+
+	original := uploadAPI.Config.API.Policy
+	uploadAPI.Config.API.Policy = config.UploadPolicy{Disabled: true}
+	defer func() { uploadAPI.Config.API.Policy = original }()
+
+	// As in TestUploader_PolicyAllowsURLWithContentTypeHint, any error returned here is a network
+	// error against server.URL, not a policy rejection.
+	_, err := uploadAPI.Upload(ctx, server.URL, uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	if errors.Is(err, uploader.ErrDisallowedContentType) {
+		t.Errorf("got %v, want no policy rejection", err)
+	}
+}
+
+func TestUploader_PolicyDisabledAllowsDeniedLocalContentType(t *testing.T) {
+	original := uploadAPI.Config.API.Policy
+	uploadAPI.Config.API.Policy = config.UploadPolicy{Disabled: true}
+	defer func() { uploadAPI.Config.API.Policy = original }()
+
+	_, err := uploadAPI.Upload(ctx, strings.NewReader(disallowedHTML), uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	if errors.Is(err, uploader.ErrDisallowedContentType) {
+		t.Errorf("got %v, want no policy rejection", err)
+	}
+}
+
+func assertDisallowedContentType(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errors.Is(err, uploader.ErrDisallowedContentType) {
+		t.Errorf("got %v, want an error wrapping uploader.ErrDisallowedContentType", err)
+	}
+}
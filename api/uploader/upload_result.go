@@ -0,0 +1,42 @@
+package uploader
+
+import (
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/api"
+)
+
+// UploadResult is the result of the Upload API.
+type UploadResult struct {
+	AssetID          string    `json:"asset_id"`
+	PublicID         string    `json:"public_id"`
+	Version          int       `json:"version"`
+	VersionID        string    `json:"version_id"`
+	Signature        string    `json:"signature"`
+	Width            int       `json:"width"`
+	Height           int       `json:"height"`
+	Format           string    `json:"format"`
+	ResourceType     string    `json:"resource_type"`
+	CreatedAt        time.Time `json:"created_at"`
+	Pages            int       `json:"pages"`
+	Bytes            int       `json:"bytes"`
+	Type             string    `json:"type"`
+	Etag             string    `json:"etag"`
+	URL              string    `json:"url"`
+	SecureURL        string    `json:"secure_url"`
+	OriginalFilename string    `json:"original_filename"`
+
+	Colors      []ColorWeight            `json:"colors,omitempty"`
+	Predominant map[string][]ColorWeight `json:"predominant,omitempty"`
+	Phash       string                   `json:"phash,omitempty"`
+
+	// BlurHash is the locally-computed BlurHash placeholder string, populated when
+	// UploadParams.BlurHash was set on an image upload.
+	BlurHash string `json:"-"`
+
+	Context map[string]interface{} `json:"context,omitempty"`
+
+	ResponsiveBreakpoints ResponsiveBreakpointsResult `json:"responsive_breakpoints,omitempty"`
+
+	Error api.Error `json:"error,omitempty"`
+}
@@ -0,0 +1,16 @@
+package uploader
+
+import "testing"
+
+func TestStructToParamsContext(t *testing.T) {
+	params := UploadParams{
+		PublicID: "go_test",
+		Context:  map[string]string{"alt": "hello", "blurhash": "abc"},
+	}
+
+	got := structToParams(params)["context"]
+	want := "alt=hello|blurhash=abc"
+	if got != want {
+		t.Errorf("got context %q, want %q", got, want)
+	}
+}
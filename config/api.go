@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// API holds configuration that controls how requests are sent to the Cloudinary API.
+type API struct {
+	UploadPrefix string
+	Timeout      time.Duration
+
+	// ChunkSize is the size, in bytes, of each chunk when uploading a file larger than ChunkSize.
+	ChunkSize int64
+
+	// ChunkConcurrency is the number of chunks uploaded in parallel for a single chunked upload.
+	// A value of 1 (the default) preserves the historical sequential behaviour.
+	ChunkConcurrency int
+
+	// Policy pre-validates the resolved content type of an upload source before any HTTP request
+	// is issued. The zero value rejects DefaultDeniedContentTypes and allows everything else.
+	Policy UploadPolicy
+}
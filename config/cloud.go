@@ -0,0 +1,8 @@
+package config
+
+// Cloud holds the Cloudinary account credentials used to sign and address API requests.
+type Cloud struct {
+	CloudName string
+	APIKey    string
+	APISecret string
+}
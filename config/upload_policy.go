@@ -0,0 +1,64 @@
+package config
+
+import "strings"
+
+// DefaultDeniedContentTypes are rejected by a zero-value UploadPolicy, covering MIME types
+// commonly used to smuggle executable or script content through an upload endpoint.
+var DefaultDeniedContentTypes = []string{
+	"application/javascript",
+	"application/ecmascript",
+	"text/javascript",
+	"text/html",
+	"application/x-javascript",
+}
+
+// UploadPolicy pre-validates the resolved MIME type of an upload source before any HTTP request
+// is issued.
+type UploadPolicy struct {
+	// Disabled skips content-type validation entirely: Allowed always returns true, and a remote
+	// URL source is uploaded without the HEAD probe that would otherwise resolve its content
+	// type. Set this for callers that can't guarantee every upload source responds cleanly to
+	// HEAD with a Content-Type header, since the zero-value policy fails closed on sources it
+	// can't resolve.
+	Disabled bool
+
+	// Deny lists content types that are rejected outright. The zero value uses
+	// DefaultDeniedContentTypes.
+	Deny []string
+
+	// Allow, if non-empty, restricts uploads to only these content types, on top of Deny.
+	Allow []string
+}
+
+// Allowed reports whether contentType is permitted by the policy. Any "; charset=..." style
+// parameters are ignored when matching against Deny/Allow.
+func (p UploadPolicy) Allowed(contentType string) bool {
+	if p.Disabled {
+		return true
+	}
+
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+
+	deny := p.Deny
+	if deny == nil {
+		deny = DefaultDeniedContentTypes
+	}
+	for _, d := range deny {
+		if d == contentType {
+			return false
+		}
+	}
+
+	if len(p.Allow) == 0 {
+		return true
+	}
+	for _, a := range p.Allow {
+		if a == contentType {
+			return true
+		}
+	}
+	return false
+}
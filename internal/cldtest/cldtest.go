@@ -0,0 +1,30 @@
+// Package cldtest contains fixtures and helpers shared across the Cloudinary Go SDK test suites.
+package cldtest
+
+import "path/filepath"
+
+const (
+	// PublicID is the public ID used by most uploader tests.
+	PublicID = "go_test_image"
+
+	// LogoURL is a small remote image used to exercise URL-based uploads.
+	LogoURL = "https://res.cloudinary.com/demo/image/upload/cloudinary_icon.png"
+
+	// VideoURL is a small remote video used to exercise URL-based uploads.
+	VideoURL = "https://res.cloudinary.com/demo/video/upload/dog.mp4"
+
+	// Base64Image is a tiny transparent PNG encoded as a base64 data URI.
+	Base64Image = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNk+A8AAQUBAScY42YAAAAASUVORK5CYII="
+)
+
+// CldContext is a sample custom context map used by upload tests.
+var CldContext = map[string]string{"custom": "context"}
+
+// ImageFilePath is the path to a local test image fixture.
+var ImageFilePath = filepath.Join(TestDataDir(), "old_logo.png")
+
+// TestDataDir returns the absolute path to the testdata directory shared across test packages.
+func TestDataDir() string {
+	dir, _ := filepath.Abs("../../internal/cldtest/testdata")
+	return dir
+}